@@ -0,0 +1,35 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+// docsPath is where the generated registry table lives, relative to the
+// repository root this command is expected to be run from.
+const docsPath = "docs/networks.md"
+
+func main() {
+	if err := checkPorts(common.Networks); err != nil {
+		fmt.Fprintln(os.Stderr, "check-network-registry:", err)
+		os.Exit(1)
+	}
+
+	doc := buildNetworksDoc(common.Networks)
+	if err := os.MkdirAll(filepath.Dir(docsPath), 0o755); err != nil {
+		fmt.Fprintln(os.Stderr, "check-network-registry:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(docsPath, []byte(doc), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "check-network-registry:", err)
+		os.Exit(1)
+	}
+}
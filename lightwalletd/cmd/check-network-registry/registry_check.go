@@ -0,0 +1,69 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Command check-network-registry is a mechanical guardrail for
+// common.Networks: it rejects invalid or colliding RPC ports, and keeps
+// docs/networks.md in sync with the registry, the way Blockbook's
+// check-and-generate-port-registry does for its coin definitions.
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+// checkPorts validates that every network's RPCDefaultPort is a well-formed
+// TCP port and that it doesn't collide with another network's port unless
+// both opted into sharing it (NetworkParams.SharedPortOK).
+func checkPorts(networks map[string]*common.NetworkParams) error {
+	portOwners := make(map[string][]string)
+
+	for name, params := range networks {
+		port, err := strconv.Atoi(params.RPCDefaultPort)
+		if err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("network %q has an invalid RPCDefaultPort %q", name, params.RPCDefaultPort)
+		}
+		portOwners[params.RPCDefaultPort] = append(portOwners[params.RPCDefaultPort], name)
+	}
+
+	for port, owners := range portOwners {
+		if len(owners) < 2 {
+			continue
+		}
+		for _, name := range owners {
+			if !networks[name].SharedPortOK {
+				sort.Strings(owners)
+				return fmt.Errorf("networks %s all use RPC port %s without opting into SharedPortOK", strings.Join(owners, ", "), port)
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildNetworksDoc renders the current registry as the markdown table
+// checked into docs/networks.md, sorted by chain name for a stable diff.
+func buildNetworksDoc(networks map[string]*common.NetworkParams) string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("# Network registry\n\n")
+	b.WriteString("Generated by `go test ./lightwalletd/cmd/check-network-registry -update`. Do not edit by hand.\n\n")
+	b.WriteString("| Chain | RPC Port | Taddr Prefix | Sapling Activation Height |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for _, name := range names {
+		params := networks[name]
+		fmt.Fprintf(&b, "| %s | %s | `%s` | %d |\n", name, params.RPCDefaultPort, params.TaddrPrefixRegex, params.SaplingActivationHeight)
+	}
+	return b.String()
+}
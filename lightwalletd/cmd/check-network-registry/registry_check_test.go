@@ -0,0 +1,83 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+var update = flag.Bool("update", false, "regenerate docs/networks.md from the current registry")
+
+// repoDocsPath resolves docsPath relative to the repository root, since
+// `go test` runs with this package's directory as its working directory.
+func repoDocsPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join("..", "..", "..", docsPath)
+}
+
+func TestPortsAreValidAndNonColliding(t *testing.T) {
+	if err := checkPorts(common.Networks); err != nil {
+		t.Fatalf("checkPorts failed: %v", err)
+	}
+}
+
+func TestPortsRejectUnmarkedCollision(t *testing.T) {
+	networks := map[string]*common.NetworkParams{
+		"a": {RPCDefaultPort: "9999"},
+		"b": {RPCDefaultPort: "9999"},
+	}
+	if err := checkPorts(networks); err == nil {
+		t.Fatal("expected an unmarked port collision to be rejected")
+	}
+}
+
+func TestPortsAllowMarkedCollision(t *testing.T) {
+	networks := map[string]*common.NetworkParams{
+		"a": {RPCDefaultPort: "9999", SharedPortOK: true},
+		"b": {RPCDefaultPort: "9999", SharedPortOK: true},
+	}
+	if err := checkPorts(networks); err != nil {
+		t.Fatalf("expected a mutually opted-in port collision to be allowed, got: %v", err)
+	}
+}
+
+func TestPortsRejectInvalidPort(t *testing.T) {
+	networks := map[string]*common.NetworkParams{
+		"a": {RPCDefaultPort: "not-a-port"},
+	}
+	if err := checkPorts(networks); err == nil {
+		t.Fatal("expected a non-numeric port to be rejected")
+	}
+}
+
+// TestNetworksDocUpToDate keeps docs/networks.md mechanically in sync with
+// common.Networks: `go test ./lightwalletd/cmd/check-network-registry
+// -update` regenerates it, and a plain `go test` run fails if the checked-in
+// file has drifted from what the registry would generate.
+func TestNetworksDocUpToDate(t *testing.T) {
+	want := buildNetworksDoc(common.Networks)
+	path := repoDocsPath(t)
+
+	if *update {
+		if err := os.WriteFile(path, []byte(want), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+		return
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s (run with -update to generate it): %v", path, err)
+	}
+	if string(got) != want {
+		t.Errorf("%s is out of date with common.Networks; run `go test %s -update`", path, "./lightwalletd/cmd/check-network-registry")
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Command server is the lightwalletd server entry point. It parses the
+// --network-config flag and merges that file into the network registry; it
+// does not start a gRPC server.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+func main() {
+	networkConfigPath := flag.String("network-config", "",
+		"path to a JSON or TOML file of additional NetworkParams entries to merge into the built-in network registry")
+	flag.Parse()
+
+	if *networkConfigPath != "" {
+		if err := common.LoadNetworksFromFile(*networkConfigPath); err != nil {
+			log.Fatalf("loading --network-config %s: %v", *networkConfigPath, err)
+		}
+	}
+}
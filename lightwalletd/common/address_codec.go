@@ -0,0 +1,127 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"fmt"
+)
+
+// AddrKind distinguishes the two transparent address script types, which
+// share an encoding scheme but use different version bytes per network.
+type AddrKind int
+
+const (
+	// AddrKindP2PKH is a pay-to-pubkey-hash address (t1/tm/B1/...).
+	AddrKindP2PKH AddrKind = iota
+	// AddrKindP2SH is a pay-to-script-hash address (t3/t2/B2/...).
+	AddrKindP2SH
+)
+
+func (k AddrKind) String() string {
+	switch k {
+	case AddrKindP2PKH:
+		return "P2PKH"
+	case AddrKindP2SH:
+		return "P2SH"
+	default:
+		return "unknown"
+	}
+}
+
+// AddressCodec carries the base58check version bytes a network uses for
+// each transparent address script type. Zcash-derived chains use two
+// version bytes (unlike Bitcoin's one) ahead of the 20-byte script hash.
+type AddressCodec struct {
+	P2PKHPrefix [2]byte
+	P2SHPrefix  [2]byte
+}
+
+// addressCodecs maps chain name to its AddressCodec. Not every chain name
+// in Networks has an entry here yet; DecodeTaddr/EncodeTaddr report a clear
+// error for chains that don't.
+var addressCodecs = map[string]*AddressCodec{
+	"main": {
+		P2PKHPrefix: [2]byte{0x1C, 0xB8}, // t1...
+		P2SHPrefix:  [2]byte{0x1C, 0xBD}, // t3...
+	},
+	"test": {
+		P2PKHPrefix: [2]byte{0x1D, 0x25}, // tm...
+		P2SHPrefix:  [2]byte{0x1C, 0xBA}, // t2...
+	},
+	"regtest": {
+		P2PKHPrefix: [2]byte{0x1D, 0x25}, // tm...
+		P2SHPrefix:  [2]byte{0x1C, 0xBA}, // t2...
+	},
+	"botcash": {
+		P2PKHPrefix: [2]byte{0x05, 0xA2}, // B1...
+		P2SHPrefix:  [2]byte{0x05, 0xA4}, // B2...
+	},
+	"botcash-test": {
+		P2PKHPrefix: [2]byte{0x05, 0xA7}, // B3...
+		P2SHPrefix:  [2]byte{0x05, 0xA9}, // B4...
+	},
+}
+
+// GetAddressCodec returns the AddressCodec for a chain name, or nil if the
+// chain has none registered.
+func GetAddressCodec(chainName string) *AddressCodec {
+	return addressCodecs[chainName]
+}
+
+// DecodeTaddr decodes a transparent address into its 20-byte script hash
+// and script kind (P2PKH or P2SH), verifying the base58check checksum and
+// matching the version bytes against chainName's AddressCodec. Unlike the
+// old TaddrPrefixRegex approach, a checksum failure or a prefix that
+// belongs to neither script kind on this chain is rejected outright rather
+// than only checking the first two characters.
+func DecodeTaddr(addr string, chainName string) (scriptHash []byte, kind AddrKind, err error) {
+	codec := GetAddressCodec(chainName)
+	if codec == nil {
+		return nil, 0, fmt.Errorf("no address codec registered for chain %q", chainName)
+	}
+
+	version, payload, err := base58CheckDecode(addr, 2)
+	if err != nil {
+		return nil, 0, fmt.Errorf("decoding taddr %q: %w", addr, err)
+	}
+	if len(payload) != 20 {
+		return nil, 0, fmt.Errorf("decoding taddr %q: script hash is %d bytes, want 20", addr, len(payload))
+	}
+
+	switch [2]byte{version[0], version[1]} {
+	case codec.P2PKHPrefix:
+		return payload, AddrKindP2PKH, nil
+	case codec.P2SHPrefix:
+		return payload, AddrKindP2SH, nil
+	default:
+		return nil, 0, fmt.Errorf("decoding taddr %q: version bytes %02x%02x do not belong to chain %q", addr, version[0], version[1], chainName)
+	}
+}
+
+// EncodeTaddr encodes a 20-byte script hash as a transparent address of the
+// given kind on chainName, using base58check with that chain's version
+// bytes.
+func EncodeTaddr(scriptHash []byte, kind AddrKind, chainName string) (string, error) {
+	codec := GetAddressCodec(chainName)
+	if codec == nil {
+		return "", fmt.Errorf("no address codec registered for chain %q", chainName)
+	}
+	if len(scriptHash) != 20 {
+		return "", fmt.Errorf("script hash is %d bytes, want 20", len(scriptHash))
+	}
+
+	var version [2]byte
+	switch kind {
+	case AddrKindP2PKH:
+		version = codec.P2PKHPrefix
+	case AddrKindP2SH:
+		version = codec.P2SHPrefix
+	default:
+		return "", fmt.Errorf("unknown address kind %v", kind)
+	}
+
+	return base58CheckEncode(version[:], scriptHash), nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import "testing"
+
+func TestDecodeEncodeTaddrRoundTrip(t *testing.T) {
+	scriptHash := make([]byte, 20)
+	for i := range scriptHash {
+		scriptHash[i] = byte(i)
+	}
+
+	tests := []struct {
+		chainName string
+		kind      AddrKind
+	}{
+		{"main", AddrKindP2PKH},
+		{"main", AddrKindP2SH},
+		{"test", AddrKindP2PKH},
+		{"test", AddrKindP2SH},
+		{"botcash", AddrKindP2PKH},
+		{"botcash", AddrKindP2SH},
+		{"botcash-test", AddrKindP2PKH},
+		{"botcash-test", AddrKindP2SH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chainName+"/"+tt.kind.String(), func(t *testing.T) {
+			addr, err := EncodeTaddr(scriptHash, tt.kind, tt.chainName)
+			if err != nil {
+				t.Fatalf("EncodeTaddr failed: %v", err)
+			}
+
+			decodedHash, decodedKind, err := DecodeTaddr(addr, tt.chainName)
+			if err != nil {
+				t.Fatalf("DecodeTaddr(%q) failed: %v", addr, err)
+			}
+			if decodedKind != tt.kind {
+				t.Errorf("decoded kind = %v, want %v", decodedKind, tt.kind)
+			}
+			if string(decodedHash) != string(scriptHash) {
+				t.Errorf("decoded script hash = %x, want %x", decodedHash, scriptHash)
+			}
+		})
+	}
+}
+
+func TestDecodeTaddrBotcashPrefix(t *testing.T) {
+	scriptHash := make([]byte, 20)
+	addr, err := EncodeTaddr(scriptHash, AddrKindP2PKH, "botcash")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	if addr[:2] != "B1" {
+		t.Errorf("Botcash P2PKH address %q does not start with B1", addr)
+	}
+}
+
+func TestDecodeTaddrChecksumFailure(t *testing.T) {
+	scriptHash := make([]byte, 20)
+	addr, err := EncodeTaddr(scriptHash, AddrKindP2PKH, "main")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+
+	// Flip the last character, which is part of the checksum tail, so the
+	// string decodes to base58 fine but fails the checksum.
+	mangled := []byte(addr)
+	if mangled[len(mangled)-1] == 'a' {
+		mangled[len(mangled)-1] = 'b'
+	} else {
+		mangled[len(mangled)-1] = 'a'
+	}
+
+	if _, _, err := DecodeTaddr(string(mangled), "main"); err == nil {
+		t.Error("expected checksum failure, got nil error")
+	}
+}
+
+func TestDecodeTaddrWrongNetworkRejected(t *testing.T) {
+	scriptHash := make([]byte, 20)
+	zcashAddr, err := EncodeTaddr(scriptHash, AddrKindP2PKH, "main")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+
+	if _, _, err := DecodeTaddr(zcashAddr, "botcash"); err == nil {
+		t.Error("expected a Zcash mainnet address to be rejected on botcash, got nil error")
+	}
+}
+
+func TestDecodeTaddrUnknownChain(t *testing.T) {
+	if _, _, err := DecodeTaddr("B1abc", "no-such-chain"); err == nil {
+		t.Error("expected an error for an unregistered chain, got nil")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+)
+
+// base58Alphabet is the Bitcoin/Zcash base58 alphabet: digits and letters
+// with 0, O, I and l removed to avoid visual ambiguity.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Decode = func() [256]int8 {
+	var table [256]int8
+	for i := range table {
+		table[i] = -1
+	}
+	for i, c := range base58Alphabet {
+		table[byte(c)] = int8(i)
+	}
+	return table
+}()
+
+// base58Encode encodes data using the base58 alphabet, preserving leading
+// zero bytes as leading '1' characters the way Bitcoin-style base58 does.
+func base58Encode(data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(data)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	result := make([]byte, zeros+len(out))
+	for i := 0; i < zeros; i++ {
+		result[i] = base58Alphabet[0]
+	}
+	copy(result[zeros:], out)
+	return string(result)
+}
+
+// base58DecodeString decodes a base58 string back to bytes, preserving leading
+// '1' characters as leading zero bytes. Returns an error if s contains any
+// character outside the base58 alphabet.
+func base58DecodeString(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		digit := base58Decode[s[i]]
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q at position %d", s[i], i)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	decoded := n.Bytes()
+	result := make([]byte, zeros+len(decoded))
+	copy(result[zeros:], decoded)
+	return result, nil
+}
+
+// doubleSHA256 returns SHA256(SHA256(data)), the checksum digest used
+// throughout the Bitcoin/Zcash base58check format.
+func doubleSHA256(data []byte) [32]byte {
+	first := sha256.Sum256(data)
+	return sha256.Sum256(first[:])
+}
+
+// base58CheckEncode prepends version to payload, appends a 4-byte
+// double-SHA256 checksum, and base58-encodes the result.
+func base58CheckEncode(version []byte, payload []byte) string {
+	data := make([]byte, 0, len(version)+len(payload)+4)
+	data = append(data, version...)
+	data = append(data, payload...)
+	checksum := doubleSHA256(data)
+	data = append(data, checksum[:4]...)
+	return base58Encode(data)
+}
+
+// base58CheckDecode reverses base58CheckEncode: it decodes s, verifies the
+// trailing 4-byte checksum, and splits the remainder into the versionLen
+// leading version bytes and the payload that follows. It fails closed on
+// any checksum mismatch or undersized input, which is what lets callers
+// distinguish a malformed or wrong-network address from a well-formed one
+// instead of only checking a two-character prefix.
+func base58CheckDecode(s string, versionLen int) (version []byte, payload []byte, err error) {
+	data, err := base58DecodeString(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) < versionLen+4 {
+		return nil, nil, fmt.Errorf("base58check payload too short: got %d bytes, want at least %d", len(data), versionLen+4)
+	}
+
+	body := data[:len(data)-4]
+	checksum := data[len(data)-4:]
+	want := doubleSHA256(body)
+	for i := 0; i < 4; i++ {
+		if checksum[i] != want[i] {
+			return nil, nil, fmt.Errorf("base58check checksum mismatch")
+		}
+	}
+
+	return body[:versionLen], body[versionLen:], nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import "testing"
+
+func TestBase58EncodeDecodeRoundTrip(t *testing.T) {
+	inputs := [][]byte{
+		{0x00},
+		{0x00, 0x00, 0x01, 0x02, 0x03},
+		{0xff, 0xff, 0xff, 0xff},
+		[]byte("hello botcash"),
+	}
+
+	for _, in := range inputs {
+		encoded := base58Encode(in)
+		decoded, err := base58DecodeString(encoded)
+		if err != nil {
+			t.Fatalf("base58DecodeString(%q) failed: %v", encoded, err)
+		}
+		if string(decoded) != string(in) {
+			t.Errorf("round trip mismatch: got %x, want %x", decoded, in)
+		}
+	}
+}
+
+func TestBase58DecodeInvalidCharacter(t *testing.T) {
+	if _, err := base58DecodeString("0OIl"); err == nil {
+		t.Error("expected an error decoding characters outside the base58 alphabet")
+	}
+}
+
+func TestBase58CheckDecodeDetectsCorruption(t *testing.T) {
+	encoded := base58CheckEncode([]byte{0x1C, 0xB8}, make([]byte, 20))
+	if _, _, err := base58CheckDecode(encoded, 2); err != nil {
+		t.Fatalf("unexpected error decoding a valid base58check string: %v", err)
+	}
+
+	if _, _, err := base58CheckDecode(encoded[:len(encoded)-1], 2); err == nil {
+		t.Error("expected a truncated base58check string to fail checksum verification")
+	}
+}
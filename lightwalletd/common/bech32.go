@@ -0,0 +1,139 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bech32Charset is the BIP-173/350 base32 alphabet.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32mConst is the BIP-350 (bech32m) checksum constant, used instead of
+// bech32's 1 so that Orchard-era addresses can't be confused with an
+// original bech32-encoded string.
+const bech32mConst = 0x2bc830a3
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 != 0 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+func bech32CreateChecksum(hrp string, data []byte) []byte {
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := bech32Polymod(values) ^ bech32mConst
+	checksum := make([]byte, 6)
+	for i := 0; i < 6; i++ {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+func bech32VerifyChecksum(hrp string, data []byte) bool {
+	values := append(bech32HRPExpand(hrp), data...)
+	return bech32Polymod(values) == bech32mConst
+}
+
+// bech32mEncode encodes data (a slice of bytes already grouped into 5-bit
+// values) as "hrp1<data><checksum>", using the bech32m checksum variant.
+func bech32mEncode(hrp string, data []byte) string {
+	combined := append(append([]byte{}, data...), bech32CreateChecksum(hrp, data)...)
+	var b strings.Builder
+	b.WriteString(hrp)
+	b.WriteByte('1')
+	for _, v := range combined {
+		b.WriteByte(bech32Charset[v])
+	}
+	return b.String()
+}
+
+// bech32mDecode splits and validates a bech32m string, returning its HRP
+// and 5-bit-grouped data (checksum stripped). It rejects mixed-case input,
+// since a string that mixes cases can't be canonicalized without risking
+// silently accepting a typo'd address.
+func bech32mDecode(s string) (hrp string, data []byte, err error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, fmt.Errorf("mixed-case bech32 string %q", s)
+	}
+	lower := strings.ToLower(s)
+
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+7 > len(lower) {
+		return "", nil, fmt.Errorf("invalid bech32 string %q: separator '1' missing or data too short", s)
+	}
+	hrp = lower[:sep]
+	dataPart := lower[sep+1:]
+
+	data = make([]byte, len(dataPart))
+	for i := 0; i < len(dataPart); i++ {
+		idx := strings.IndexByte(bech32Charset, dataPart[i])
+		if idx < 0 {
+			return "", nil, fmt.Errorf("invalid bech32 character %q in %q", dataPart[i], s)
+		}
+		data[i] = byte(idx)
+	}
+
+	if !bech32VerifyChecksum(hrp, data) {
+		return "", nil, fmt.Errorf("bech32m checksum mismatch in %q", s)
+	}
+	return hrp, data[:len(data)-6], nil
+}
+
+// convertBits repacks a slice of fromBits-wide values into a slice of
+// toBits-wide values, as used to go between 8-bit address bytes and
+// bech32's 5-bit alphabet.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc uint32
+	var bits uint
+	maxv := uint32(1)<<toBits - 1
+	var out []byte
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d for %d-bit input", value, fromBits)
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte((acc>>bits)&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			out = append(out, byte((acc<<(toBits-bits))&maxv))
+		}
+	} else if bits >= fromBits || (acc<<(toBits-bits))&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding in bit conversion")
+	}
+
+	return out, nil
+}
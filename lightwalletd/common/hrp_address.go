@@ -0,0 +1,66 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import "fmt"
+
+// ToHRPAddress converts a legacy base58check transparent address into the
+// optional bech32m human-readable form for chainName, e.g.
+// "B1..." -> "botcash1...". It's the dual-encoding counterpart to
+// DecodeTaddr/EncodeTaddr, following the legacy-vs-CashAddr pattern some
+// other chains use for a more typo-resistant address format.
+func ToHRPAddress(legacy string, chainName string) (string, error) {
+	params := GetNetworkParams(chainName)
+	if params == nil || params.HRP == "" {
+		return "", fmt.Errorf("chain %q has no HRP address encoding registered", chainName)
+	}
+
+	scriptHash, kind, err := DecodeTaddr(legacy, chainName)
+	if err != nil {
+		return "", fmt.Errorf("converting %q to HRP form: %w", legacy, err)
+	}
+
+	payload := append([]byte{byte(kind)}, scriptHash...)
+	data, err := convertBits(payload, 8, 5, true)
+	if err != nil {
+		return "", fmt.Errorf("converting %q to HRP form: %w", legacy, err)
+	}
+
+	return bech32mEncode(params.HRP, data), nil
+}
+
+// FromHRPAddress converts a bech32m address back to the canonical legacy
+// base58check form for chainName, rejecting addresses with the wrong HRP,
+// mixed case, or a bad checksum.
+func FromHRPAddress(hrpAddr string, chainName string) (legacy string, err error) {
+	params := GetNetworkParams(chainName)
+	if params == nil || params.HRP == "" {
+		return "", fmt.Errorf("chain %q has no HRP address encoding registered", chainName)
+	}
+
+	hrp, data, err := bech32mDecode(hrpAddr)
+	if err != nil {
+		return "", fmt.Errorf("decoding HRP address %q: %w", hrpAddr, err)
+	}
+	if hrp != params.HRP {
+		return "", fmt.Errorf("decoding HRP address %q: got HRP %q, want %q for chain %q", hrpAddr, hrp, params.HRP, chainName)
+	}
+
+	payload, err := convertBits(data, 5, 8, false)
+	if err != nil {
+		return "", fmt.Errorf("decoding HRP address %q: %w", hrpAddr, err)
+	}
+	if len(payload) != 21 {
+		return "", fmt.Errorf("decoding HRP address %q: payload is %d bytes, want 21", hrpAddr, len(payload))
+	}
+
+	kind := AddrKind(payload[0])
+	if kind != AddrKindP2PKH && kind != AddrKindP2SH {
+		return "", fmt.Errorf("decoding HRP address %q: unknown address kind %d", hrpAddr, payload[0])
+	}
+
+	return EncodeTaddr(payload[1:], kind, chainName)
+}
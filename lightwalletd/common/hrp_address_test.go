@@ -0,0 +1,106 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHRPAddressRoundTrip(t *testing.T) {
+	scriptHash := make([]byte, 20)
+	for i := range scriptHash {
+		scriptHash[i] = byte(i * 3)
+	}
+
+	tests := []struct {
+		chainName string
+		kind      AddrKind
+	}{
+		{"botcash", AddrKindP2PKH},
+		{"botcash", AddrKindP2SH},
+		{"botcash-test", AddrKindP2PKH},
+		{"botcash-test", AddrKindP2SH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.chainName+"/"+tt.kind.String(), func(t *testing.T) {
+			legacy, err := EncodeTaddr(scriptHash, tt.kind, tt.chainName)
+			if err != nil {
+				t.Fatalf("EncodeTaddr failed: %v", err)
+			}
+
+			hrpAddr, err := ToHRPAddress(legacy, tt.chainName)
+			if err != nil {
+				t.Fatalf("ToHRPAddress failed: %v", err)
+			}
+			if !strings.HasPrefix(hrpAddr, Networks[tt.chainName].HRP+"1") {
+				t.Errorf("HRP address %q does not start with the registered HRP", hrpAddr)
+			}
+
+			roundTripped, err := FromHRPAddress(hrpAddr, tt.chainName)
+			if err != nil {
+				t.Fatalf("FromHRPAddress failed: %v", err)
+			}
+			if roundTripped != legacy {
+				t.Errorf("round trip mismatch: got %q, want %q", roundTripped, legacy)
+			}
+		})
+	}
+}
+
+func TestFromHRPAddressRejectsMixedCase(t *testing.T) {
+	legacy, err := EncodeTaddr(make([]byte, 20), AddrKindP2PKH, "botcash")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	hrpAddr, err := ToHRPAddress(legacy, "botcash")
+	if err != nil {
+		t.Fatalf("ToHRPAddress failed: %v", err)
+	}
+
+	mixed := []byte(hrpAddr)
+	changed := false
+	for i, c := range mixed {
+		if c >= 'a' && c <= 'z' {
+			mixed[i] = c - ('a' - 'A')
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		t.Fatal("test setup error: no lowercase letter found to flip")
+	}
+
+	if _, err := FromHRPAddress(string(mixed), "botcash"); err == nil {
+		t.Error("expected a mixed-case HRP address to be rejected")
+	}
+}
+
+func TestFromHRPAddressRejectsWrongHRP(t *testing.T) {
+	legacy, err := EncodeTaddr(make([]byte, 20), AddrKindP2PKH, "botcash")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	hrpAddr, err := ToHRPAddress(legacy, "botcash")
+	if err != nil {
+		t.Fatalf("ToHRPAddress failed: %v", err)
+	}
+
+	if _, err := FromHRPAddress(hrpAddr, "botcash-test"); err == nil {
+		t.Error("expected an address with botcash's HRP to be rejected on botcash-test")
+	}
+}
+
+func TestToHRPAddressUnregisteredChain(t *testing.T) {
+	legacy, err := EncodeTaddr(make([]byte, 20), AddrKindP2PKH, "main")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	if _, err := ToHRPAddress(legacy, "main"); err == nil {
+		t.Error("expected Zcash mainnet, which has no HRP registered, to be rejected")
+	}
+}
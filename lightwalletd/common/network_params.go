@@ -5,13 +5,67 @@
 
 package common
 
+// NetworkUpgrade describes a single network upgrade (NU) activation: the
+// block height at which it takes effect and the consensus branch ID that
+// v4/v5 transactions and blocks use once it is active. Branch IDs are the
+// little-endian uint32 values zcashd advertises in its "upgrades" field of
+// getblockchaininfo, and they're what transaction signing/parsing binds to
+// instead of the upgrade name.
+type NetworkUpgrade struct {
+	ActivationHeight uint64 `json:"activationHeight"`
+	BranchID         uint32 `json:"branchId"`
+}
+
+// Canonical network upgrade names, oldest first. Upgrades activates are
+// cumulative: once NU5 is active, Canopy/Heartwood/etc. rules that weren't
+// superseded still apply, but the branch ID used for parsing/signing is
+// always the one belonging to the highest upgrade active at a given height.
+const (
+	UpgradeOverwinter = "Overwinter"
+	UpgradeSapling    = "Sapling"
+	UpgradeBlossom    = "Blossom"
+	UpgradeHeartwood  = "Heartwood"
+	UpgradeCanopy     = "Canopy"
+	UpgradeNU5        = "NU5"
+)
+
+// upgradeOrder lists upgrades oldest-to-newest so GetActiveUpgrade can walk
+// it to find the highest one already activated at a given height.
+var upgradeOrder = []string{
+	UpgradeOverwinter,
+	UpgradeSapling,
+	UpgradeBlossom,
+	UpgradeHeartwood,
+	UpgradeCanopy,
+	UpgradeNU5,
+}
+
+// preOverwinterBranchID is used below the Overwinter activation height,
+// where transactions don't carry a consensus branch ID at all.
+const preOverwinterBranchID uint32 = 0x00000000
+
 // NetworkParams defines network-specific parameters for different blockchain networks.
 // This includes RPC ports, address prefixes, and other network-specific configuration.
 type NetworkParams struct {
-	Name                    string // Network name as returned by getblockchaininfo RPC
-	RPCDefaultPort          string // Default RPC port for this network
-	TaddrPrefixRegex        string // Regex pattern for transparent address prefix validation
-	SaplingActivationHeight uint64 // Block height at which Sapling activated
+	Name                    string `json:"name"`                    // Network name as returned by getblockchaininfo RPC
+	RPCDefaultPort          string `json:"rpcDefaultPort"`          // Default RPC port for this network
+	TaddrPrefixRegex        string `json:"taddrPrefixRegex"`        // Regex pattern for transparent address prefix validation
+	SaplingActivationHeight uint64 `json:"saplingActivationHeight"` // Block height at which Sapling activated
+
+	// SharedPortOK opts this network into sharing its RPCDefaultPort with
+	// another network that also sets it, e.g. test and regtest both
+	// listening on 18232. cmd/check-network-registry treats an unmarked
+	// collision as an error.
+	SharedPortOK bool `json:"sharedPortOk,omitempty"`
+
+	// HRP is the bech32m human-readable part for this network's optional
+	// dual CashAddr-style transparent address encoding. Empty if the
+	// network only supports the base58check legacy form.
+	HRP string `json:"hrp,omitempty"`
+
+	// Upgrades maps network upgrade name (see the Upgrade* constants) to its
+	// activation height and consensus branch ID on this network.
+	Upgrades map[string]NetworkUpgrade `json:"upgrades,omitempty"`
 }
 
 // Networks maps chain names to their network parameters.
@@ -23,18 +77,46 @@ var Networks = map[string]*NetworkParams{
 		RPCDefaultPort:          "8232",
 		TaddrPrefixRegex:        "^t1",
 		SaplingActivationHeight: 419200,
+		Upgrades: map[string]NetworkUpgrade{
+			UpgradeOverwinter: {ActivationHeight: 347500, BranchID: 0x5ba81b19},
+			UpgradeSapling:    {ActivationHeight: 419200, BranchID: 0x76b809bb},
+			UpgradeBlossom:    {ActivationHeight: 653600, BranchID: 0x2bb40e60},
+			UpgradeHeartwood:  {ActivationHeight: 903000, BranchID: 0xf5b9230b},
+			UpgradeCanopy:     {ActivationHeight: 1046400, BranchID: 0xe9ff75a6},
+			UpgradeNU5:        {ActivationHeight: 1687104, BranchID: 0xc2d6d0b4},
+		},
 	},
 	"test": {
 		Name:                    "test",
 		RPCDefaultPort:          "18232",
 		TaddrPrefixRegex:        "^tm",
 		SaplingActivationHeight: 280000,
+		SharedPortOK:            true, // shares 18232 with regtest
+
+		Upgrades: map[string]NetworkUpgrade{
+			UpgradeOverwinter: {ActivationHeight: 207500, BranchID: 0x5ba81b19},
+			UpgradeSapling:    {ActivationHeight: 280000, BranchID: 0x76b809bb},
+			UpgradeBlossom:    {ActivationHeight: 584000, BranchID: 0x2bb40e60},
+			UpgradeHeartwood:  {ActivationHeight: 903800, BranchID: 0xf5b9230b},
+			UpgradeCanopy:     {ActivationHeight: 1028500, BranchID: 0xe9ff75a6},
+			UpgradeNU5:        {ActivationHeight: 1842420, BranchID: 0xc2d6d0b4},
+		},
 	},
 	"regtest": {
 		Name:                    "regtest",
 		RPCDefaultPort:          "18232",
 		TaddrPrefixRegex:        "^tm",
 		SaplingActivationHeight: 1,
+		SharedPortOK:            true, // shares 18232 with test
+
+		Upgrades: map[string]NetworkUpgrade{
+			UpgradeOverwinter: {ActivationHeight: 1, BranchID: 0x5ba81b19},
+			UpgradeSapling:    {ActivationHeight: 1, BranchID: 0x76b809bb},
+			UpgradeBlossom:    {ActivationHeight: 1, BranchID: 0x2bb40e60},
+			UpgradeHeartwood:  {ActivationHeight: 1, BranchID: 0xf5b9230b},
+			UpgradeCanopy:     {ActivationHeight: 1, BranchID: 0xe9ff75a6},
+			UpgradeNU5:        {ActivationHeight: 1, BranchID: 0xc2d6d0b4},
+		},
 	},
 
 	// Botcash networks
@@ -43,12 +125,32 @@ var Networks = map[string]*NetworkParams{
 		RPCDefaultPort:          "8532",
 		TaddrPrefixRegex:        "^B1",
 		SaplingActivationHeight: 1, // Sapling active from genesis on Botcash
+		HRP:                     "botcash",
+		Upgrades: map[string]NetworkUpgrade{
+			// Botcash launched with the full modern Zcash consensus rule
+			// set already active, so every upgrade activates at genesis.
+			UpgradeOverwinter: {ActivationHeight: 1, BranchID: 0x5ba81b19},
+			UpgradeSapling:    {ActivationHeight: 1, BranchID: 0x76b809bb},
+			UpgradeBlossom:    {ActivationHeight: 1, BranchID: 0x2bb40e60},
+			UpgradeHeartwood:  {ActivationHeight: 1, BranchID: 0xf5b9230b},
+			UpgradeCanopy:     {ActivationHeight: 1, BranchID: 0xe9ff75a6},
+			UpgradeNU5:        {ActivationHeight: 1, BranchID: 0xc2d6d0b4},
+		},
 	},
 	"botcash-test": {
 		Name:                    "botcash-test",
 		RPCDefaultPort:          "18532",
 		TaddrPrefixRegex:        "^B1",
 		SaplingActivationHeight: 1, // Sapling active from genesis on Botcash testnet
+		HRP:                     "botcashtest",
+		Upgrades: map[string]NetworkUpgrade{
+			UpgradeOverwinter: {ActivationHeight: 1, BranchID: 0x5ba81b19},
+			UpgradeSapling:    {ActivationHeight: 1, BranchID: 0x76b809bb},
+			UpgradeBlossom:    {ActivationHeight: 1, BranchID: 0x2bb40e60},
+			UpgradeHeartwood:  {ActivationHeight: 1, BranchID: 0xf5b9230b},
+			UpgradeCanopy:     {ActivationHeight: 1, BranchID: 0xe9ff75a6},
+			UpgradeNU5:        {ActivationHeight: 1, BranchID: 0xc2d6d0b4},
+		},
 	},
 }
 
@@ -79,6 +181,36 @@ func GetTaddrPrefixRegex(chainName string) string {
 	return "^t1" // Default fallback to Zcash mainnet
 }
 
+// GetActiveUpgrade returns the name and consensus branch ID of the highest
+// network upgrade active at the given height on chainName. If no upgrade
+// has activated yet (height is before Overwinter), it returns ("",
+// preOverwinterBranchID). Unrecognized chain names fall back to Zcash
+// mainnet, matching the rest of this package's Get* helpers.
+func GetActiveUpgrade(chainName string, height uint64) (name string, branchID uint32) {
+	params := GetNetworkParams(chainName)
+	if params == nil {
+		params = Networks["main"]
+	}
+
+	for i := len(upgradeOrder) - 1; i >= 0; i-- {
+		upgradeName := upgradeOrder[i]
+		upgrade, ok := params.Upgrades[upgradeName]
+		if ok && height >= upgrade.ActivationHeight {
+			return upgradeName, upgrade.BranchID
+		}
+	}
+	return "", preOverwinterBranchID
+}
+
+// GetBranchIDAtHeight returns the consensus branch ID in effect at the given
+// height on chainName, for use when parsing or verifying v4/v5 transactions
+// and blocks. It's a thin convenience wrapper around GetActiveUpgrade for
+// callers that don't care which upgrade introduced the branch ID.
+func GetBranchIDAtHeight(chainName string, height uint64) uint32 {
+	_, branchID := GetActiveUpgrade(chainName, height)
+	return branchID
+}
+
 // IsBotcashNetwork returns true if the chain name indicates a Botcash network.
 func IsBotcashNetwork(chainName string) bool {
 	return chainName == "botcash" || chainName == "botcash-test"
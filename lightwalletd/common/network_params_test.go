@@ -156,19 +156,58 @@ func TestIsZcashNetwork(t *testing.T) {
 	}
 }
 
+func TestGetActiveUpgradeCrossesBoundary(t *testing.T) {
+	tests := []struct {
+		name             string
+		chainName        string
+		height           uint64
+		expectedUpgrade  string
+		expectedBranchID uint32
+	}{
+		{"mainnet just before NU5", "main", 1687103, UpgradeCanopy, 0xe9ff75a6},
+		{"mainnet at NU5 activation", "main", 1687104, UpgradeNU5, 0xc2d6d0b4},
+		{"mainnet before any upgrade", "main", 100, "", preOverwinterBranchID},
+		{"botcash genesis has every upgrade active", "botcash", 1, UpgradeNU5, 0xc2d6d0b4},
+		{"unknown chain falls back to mainnet", "unknown", 1687104, UpgradeNU5, 0xc2d6d0b4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, branchID := GetActiveUpgrade(tt.chainName, tt.height)
+			if name != tt.expectedUpgrade {
+				t.Errorf("GetActiveUpgrade(%s, %d) name = %q, want %q", tt.chainName, tt.height, name, tt.expectedUpgrade)
+			}
+			if branchID != tt.expectedBranchID {
+				t.Errorf("GetActiveUpgrade(%s, %d) branchID = %#08x, want %#08x", tt.chainName, tt.height, branchID, tt.expectedBranchID)
+			}
+		})
+	}
+}
+
+func TestGetBranchIDAtHeightFlipsAcrossBoundary(t *testing.T) {
+	before := GetBranchIDAtHeight("main", 419199)
+	after := GetBranchIDAtHeight("main", 419200)
+	if before == after {
+		t.Fatalf("expected branch ID to change at the Sapling boundary, got %#08x both times", before)
+	}
+	if after != 0x76b809bb {
+		t.Errorf("branch ID at Sapling activation = %#08x, want %#08x", after, 0x76b809bb)
+	}
+}
+
 func TestBotcashAddressPrefixRegex(t *testing.T) {
 	// Test that the B1 prefix regex correctly matches Botcash addresses
 	regex := regexp.MustCompile(GetTaddrPrefixRegex("botcash") + "[a-zA-Z0-9]{33}$")
 
 	validAddresses := []string{
-		"B1abcdefghijklmnopqrstuvwxyz123456", // 35 chars total (B1 + 33)
-		"B1ABCDEFGHIJKLMNOPQRSTUVWXYZ123456", // uppercase
+		"B1abcdefghijklmnopqrstuvwxyz1234567", // 35 chars total (B1 + 33)
+		"B1ABCDEFGHIJKLMNOPQRSTUVWXYZ1234567", // uppercase
 	}
 
 	invalidAddresses := []string{
-		"t1abcdefghijklmnopqrstuvwxyz123456", // Zcash prefix
-		"b1abcdefghijklmnopqrstuvwxyz123456", // lowercase b1
-		"B2abcdefghijklmnopqrstuvwxyz123456", // B2 prefix
+		"t1abcdefghijklmnopqrstuvwxyz1234567", // Zcash prefix
+		"b1abcdefghijklmnopqrstuvwxyz1234567", // lowercase b1
+		"B2abcdefghijklmnopqrstuvwxyz1234567", // B2 prefix
 		"B1abc",                               // too short
 	}
 
@@ -190,11 +229,11 @@ func TestZcashAddressPrefixRegex(t *testing.T) {
 	regex := regexp.MustCompile(GetTaddrPrefixRegex("main") + "[a-zA-Z0-9]{33}$")
 
 	validAddresses := []string{
-		"t1abcdefghijklmnopqrstuvwxyz123456", // 35 chars total (t1 + 33)
+		"t1abcdefghijklmnopqrstuvwxyz1234567", // 35 chars total (t1 + 33)
 	}
 
 	invalidAddresses := []string{
-		"B1abcdefghijklmnopqrstuvwxyz123456", // Botcash prefix
+		"B1abcdefghijklmnopqrstuvwxyz1234567", // Botcash prefix
 		"t1abc",                               // too short
 	}
 
@@ -0,0 +1,247 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// networkConfigFile is the on-disk shape LoadNetworksFromFile expects,
+// whether it's serialized as JSON or TOML: a single top-level "networks"
+// table mapping chain name to a NetworkParams-shaped record.
+type networkConfigFile struct {
+	Networks map[string]*NetworkParams `json:"networks"`
+}
+
+// LoadNetworksFromFile reads a JSON or TOML file (selected by its .json or
+// .toml extension) of NetworkParams entries and merges them into Networks,
+// so a lightwalletd operator can stand up a new Zcash fork by dropping in a
+// config file instead of patching and recompiling this package.
+//
+// The whole file is validated before anything is merged: a bad entry
+// anywhere in the file leaves Networks untouched. Entries that fail
+// validation, or that collide with an already-registered network, cause
+// LoadNetworksFromFile to fail fast rather than silently overwrite a
+// built-in network.
+func LoadNetworksFromFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading network config %s: %w", path, err)
+	}
+
+	var config networkConfigFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("parsing network config %s as JSON: %w", path, err)
+		}
+	case ".toml":
+		if err := parseNetworkConfigTOML(data, &config); err != nil {
+			return fmt.Errorf("parsing network config %s as TOML: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("network config %s: unrecognized extension %q, want .json or .toml", path, ext)
+	}
+
+	for name, params := range config.Networks {
+		if err := validateNewNetwork(name, params); err != nil {
+			return fmt.Errorf("network config %s: %w", path, err)
+		}
+	}
+	if err := validateBatchPortCollisions(config.Networks); err != nil {
+		return fmt.Errorf("network config %s: %w", path, err)
+	}
+
+	// Only merge after every entry in the file has validated cleanly, so a
+	// single bad entry can't leave Networks partially updated.
+	for name, params := range config.Networks {
+		Networks[name] = params
+	}
+	return nil
+}
+
+// validateNewNetwork checks that name/params are safe to merge into
+// Networks: name must not already be registered, RPCDefaultPort must not
+// collide with any already-registered network unless both set
+// SharedPortOK, TaddrPrefixRegex must
+// compile, and any Upgrades must activate at monotonically non-decreasing
+// heights.
+func validateNewNetwork(name string, params *NetworkParams) error {
+	if _, exists := Networks[name]; exists {
+		return fmt.Errorf("network %q is already registered, refusing to overwrite it", name)
+	}
+
+	for existingName, existing := range Networks {
+		if existing.RPCDefaultPort == params.RPCDefaultPort && !(existing.SharedPortOK && params.SharedPortOK) {
+			return fmt.Errorf("network %q RPC port %s collides with already-registered network %q", name, params.RPCDefaultPort, existingName)
+		}
+	}
+
+	if _, err := regexp.Compile(params.TaddrPrefixRegex); err != nil {
+		return fmt.Errorf("network %q taddr prefix regex %q does not compile: %w", name, params.TaddrPrefixRegex, err)
+	}
+
+	var lastHeight uint64
+	for _, upgradeName := range upgradeOrder {
+		upgrade, ok := params.Upgrades[upgradeName]
+		if !ok {
+			continue
+		}
+		if upgrade.ActivationHeight < lastHeight {
+			return fmt.Errorf("network %q upgrade %s activates at height %d, before the preceding upgrade's height %d", name, upgradeName, upgrade.ActivationHeight, lastHeight)
+		}
+		lastHeight = upgrade.ActivationHeight
+	}
+
+	return nil
+}
+
+// validateBatchPortCollisions checks RPCDefaultPort collisions between the
+// new networks being merged from a single config file, since
+// validateNewNetwork only ever compares a new entry against the
+// already-registered Networks map and so can't see its siblings in the same
+// batch. Without this, two new networks in one file could both claim the
+// same port (without mutual SharedPortOK) and both merge successfully.
+func validateBatchPortCollisions(batch map[string]*NetworkParams) error {
+	seenPort := make(map[string]string, len(batch))
+	for name, params := range batch {
+		if otherName, ok := seenPort[params.RPCDefaultPort]; ok {
+			other := batch[otherName]
+			if !(other.SharedPortOK && params.SharedPortOK) {
+				return fmt.Errorf("network %q RPC port %s collides with network %q, also being added by this config", name, params.RPCDefaultPort, otherName)
+			}
+			continue
+		}
+		seenPort[params.RPCDefaultPort] = name
+	}
+	return nil
+}
+
+// parseNetworkConfigTOML parses the restricted subset of TOML this package
+// needs: dotted table headers ("[networks.mychain]",
+// "[networks.mychain.upgrades.Sapling]"), "key = value" assignments with
+// string, integer, or bare-word (treated as string) values, and '#'
+// comments. It intentionally doesn't pull in a full TOML parser dependency
+// for a config shape this simple.
+func parseNetworkConfigTOML(data []byte, config *networkConfigFile) error {
+	config.Networks = make(map[string]*NetworkParams)
+
+	var currentNetwork *NetworkParams
+	var currentUpgradeName string
+	inUpgradeTable := false
+
+	for lineNum, rawLine := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(rawLine)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			header := strings.TrimSuffix(strings.TrimPrefix(line, "["), "]")
+			parts := strings.Split(header, ".")
+			inUpgradeTable = false
+			currentNetwork = nil
+
+			switch {
+			case len(parts) == 2 && parts[0] == "networks":
+				currentNetwork = &NetworkParams{Upgrades: map[string]NetworkUpgrade{}}
+				config.Networks[parts[1]] = currentNetwork
+			case len(parts) == 4 && parts[0] == "networks" && parts[2] == "upgrades":
+				network, ok := config.Networks[parts[1]]
+				if !ok {
+					return fmt.Errorf("line %d: table %q references unknown network %q", lineNum+1, header, parts[1])
+				}
+				currentNetwork = network
+				currentUpgradeName = parts[3]
+				inUpgradeTable = true
+				if _, ok := network.Upgrades[currentUpgradeName]; !ok {
+					network.Upgrades[currentUpgradeName] = NetworkUpgrade{}
+				}
+			default:
+				return fmt.Errorf("line %d: unsupported table header %q", lineNum+1, header)
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: expected \"key = value\", got %q", lineNum+1, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+
+		if currentNetwork == nil {
+			return fmt.Errorf("line %d: %q assignment outside of any [networks.*] table", lineNum+1, key)
+		}
+
+		if inUpgradeTable {
+			upgrade := currentNetwork.Upgrades[currentUpgradeName]
+			switch key {
+			case "activationHeight":
+				n, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return fmt.Errorf("line %d: activationHeight %q: %w", lineNum+1, value, err)
+				}
+				upgrade.ActivationHeight = n
+			case "branchId":
+				n, err := strconv.ParseUint(strings.TrimPrefix(value, "0x"), hexOrDecimalBase(value), 32)
+				if err != nil {
+					return fmt.Errorf("line %d: branchId %q: %w", lineNum+1, value, err)
+				}
+				upgrade.BranchID = uint32(n)
+			default:
+				return fmt.Errorf("line %d: unsupported upgrade field %q", lineNum+1, key)
+			}
+			currentNetwork.Upgrades[currentUpgradeName] = upgrade
+			continue
+		}
+
+		switch key {
+		case "name":
+			currentNetwork.Name = value
+		case "rpcDefaultPort":
+			currentNetwork.RPCDefaultPort = value
+		case "taddrPrefixRegex":
+			currentNetwork.TaddrPrefixRegex = value
+		case "sharedPortOk":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("line %d: sharedPortOk %q: %w", lineNum+1, value, err)
+			}
+			currentNetwork.SharedPortOK = b
+		case "hrp":
+			currentNetwork.HRP = value
+		case "saplingActivationHeight":
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("line %d: saplingActivationHeight %q: %w", lineNum+1, value, err)
+			}
+			currentNetwork.SaplingActivationHeight = n
+		default:
+			return fmt.Errorf("line %d: unsupported network field %q", lineNum+1, key)
+		}
+	}
+
+	return nil
+}
+
+// hexOrDecimalBase picks base 16 for 0x-prefixed values and base 10
+// otherwise, so branchId can be written either way in a TOML config.
+func hexOrDecimalBase(value string) int {
+	if strings.HasPrefix(value, "0x") {
+		return 16
+	}
+	return 10
+}
@@ -0,0 +1,143 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withSavedNetworks snapshots and restores the package-level Networks
+// registry around a test, since LoadNetworksFromFile mutates shared state.
+func withSavedNetworks(t *testing.T) {
+	t.Helper()
+	saved := make(map[string]*NetworkParams, len(Networks))
+	for name, params := range Networks {
+		saved[name] = params
+	}
+	t.Cleanup(func() {
+		for name := range Networks {
+			delete(Networks, name)
+		}
+		for name, params := range saved {
+			Networks[name] = params
+		}
+	})
+}
+
+func writeTestFile(t *testing.T, path string, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("failed to write test fixture %s: %v", path, err)
+	}
+}
+
+func TestLoadNetworksFromFileJSON(t *testing.T) {
+	withSavedNetworks(t)
+
+	if err := LoadNetworksFromFile(filepath.Join("testdata", "fakefork.json")); err != nil {
+		t.Fatalf("LoadNetworksFromFile failed: %v", err)
+	}
+
+	params := GetNetworkParams("fakefork")
+	if params == nil {
+		t.Fatal("expected fakefork to be registered")
+	}
+	if params.RPCDefaultPort != "28232" {
+		t.Errorf("RPCDefaultPort = %q, want %q", params.RPCDefaultPort, "28232")
+	}
+	if got, want := GetBranchIDAtHeight("fakefork", 1), uint32(2); got != want {
+		t.Errorf("GetBranchIDAtHeight(fakefork, 1) = %#x, want %#x", got, want)
+	}
+}
+
+func TestLoadNetworksFromFileTOML(t *testing.T) {
+	withSavedNetworks(t)
+
+	if err := LoadNetworksFromFile(filepath.Join("testdata", "fakefork.toml")); err != nil {
+		t.Fatalf("LoadNetworksFromFile failed: %v", err)
+	}
+
+	params := GetNetworkParams("fakefork-toml")
+	if params == nil {
+		t.Fatal("expected fakefork-toml to be registered")
+	}
+	if params.RPCDefaultPort != "28233" {
+		t.Errorf("RPCDefaultPort = %q, want %q", params.RPCDefaultPort, "28233")
+	}
+	if got, want := GetBranchIDAtHeight("fakefork-toml", 1), uint32(2); got != want {
+		t.Errorf("GetBranchIDAtHeight(fakefork-toml, 1) = %#x, want %#x", got, want)
+	}
+}
+
+func TestLoadNetworksFromFileRejectsPortCollision(t *testing.T) {
+	withSavedNetworks(t)
+
+	path := filepath.Join(t.TempDir(), "collide.json")
+	writeTestFile(t, path, `{"networks":{"collider":{"name":"collider","rpcDefaultPort":"8232","taddrPrefixRegex":"^c1"}}}`)
+
+	if err := LoadNetworksFromFile(path); err == nil {
+		t.Fatal("expected an RPC port collision with mainnet's 8232 to be rejected")
+	}
+	if GetNetworkParams("collider") != nil {
+		t.Error("collider must not be registered after a failed load")
+	}
+}
+
+func TestLoadNetworksFromFileRejectsSameFilePortCollision(t *testing.T) {
+	withSavedNetworks(t)
+
+	path := filepath.Join(t.TempDir(), "collide-batch.json")
+	writeTestFile(t, path, `{"networks":{
+		"fork-a":{"name":"fork-a","rpcDefaultPort":"28237","taddrPrefixRegex":"^f1"},
+		"fork-b":{"name":"fork-b","rpcDefaultPort":"28237","taddrPrefixRegex":"^f2"}
+	}}`)
+
+	if err := LoadNetworksFromFile(path); err == nil {
+		t.Fatal("expected two new networks sharing a port in the same file to be rejected")
+	}
+	if GetNetworkParams("fork-a") != nil || GetNetworkParams("fork-b") != nil {
+		t.Error("neither fork-a nor fork-b must be registered after a failed load")
+	}
+}
+
+func TestLoadNetworksFromFileRejectsInvalidRegex(t *testing.T) {
+	withSavedNetworks(t)
+
+	path := filepath.Join(t.TempDir(), "badregex.json")
+	writeTestFile(t, path, `{"networks":{"badregex":{"name":"badregex","rpcDefaultPort":"28234","taddrPrefixRegex":"^("}}}`)
+
+	if err := LoadNetworksFromFile(path); err == nil {
+		t.Fatal("expected a non-compiling taddr prefix regex to be rejected")
+	}
+}
+
+func TestLoadNetworksFromFileRejectsNonMonotonicUpgrades(t *testing.T) {
+	withSavedNetworks(t)
+
+	path := filepath.Join(t.TempDir(), "outoforder.json")
+	writeTestFile(t, path, `{"networks":{"outoforder":{"name":"outoforder","rpcDefaultPort":"28235","taddrPrefixRegex":"^o1",
+		"upgrades":{"Sapling":{"activationHeight":1000,"branchId":1},"Blossom":{"activationHeight":500,"branchId":2}}}}}`)
+
+	if err := LoadNetworksFromFile(path); err == nil {
+		t.Fatal("expected Blossom activating before Sapling to be rejected as non-monotonic")
+	}
+}
+
+func TestLoadNetworksFromFileRejectsOverwritingBuiltin(t *testing.T) {
+	withSavedNetworks(t)
+
+	path := filepath.Join(t.TempDir(), "overwrite.json")
+	writeTestFile(t, path, `{"networks":{"botcash":{"name":"botcash","rpcDefaultPort":"28236","taddrPrefixRegex":"^B9"}}}`)
+
+	if err := LoadNetworksFromFile(path); err == nil {
+		t.Fatal("expected an attempt to overwrite the built-in botcash network to be rejected")
+	}
+	if GetTaddrPrefixRegex("botcash") != "^B1" {
+		t.Error("botcash's built-in params must be unchanged after a failed load")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Package frontend implements the gRPC-facing services lightwalletd
+// exposes to wallets, on top of the chain state common maintains.
+package frontend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+// normalizeTaddr accepts a transparent address in either its legacy
+// base58check form or, where the chain registers one, its bech32m HRP
+// form, and returns the canonical legacy form so GetAddressTxids only ever
+// has to deal with one representation. This tree has no mempool-watch or
+// other RPC-facing layer yet for normalizeTaddr to serve beyond
+// GetAddressTxids; wiring it into one is follow-up work once that layer
+// exists.
+func normalizeTaddr(addr string, chainName string) (string, error) {
+	if params := common.GetNetworkParams(chainName); params != nil && params.HRP != "" &&
+		strings.HasPrefix(strings.ToLower(addr), params.HRP+"1") {
+		return common.FromHRPAddress(addr, chainName)
+	}
+
+	if _, _, err := common.DecodeTaddr(addr, chainName); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// AddressTxidsRequest mirrors the wallet-facing GetAddressTxids request: a
+// set of transparent addresses and the block range to search them over.
+type AddressTxidsRequest struct {
+	Addresses   []string
+	ChainName   string
+	StartHeight uint64
+	EndHeight   uint64
+}
+
+// GetAddressTxids accepts a batch of transparent addresses in either their
+// legacy or bech32m HRP form and normalizes each to its canonical legacy
+// form via normalizeTaddr, so a mixed-form request from a client doesn't
+// need special-casing downstream. This tree doesn't yet have a zcashd RPC
+// client to actually look up matching txids over [StartHeight, EndHeight],
+// so GetAddressTxids stops at returning the normalized address list;
+// wiring in the real txid lookup is follow-up work once that RPC client
+// exists.
+func GetAddressTxids(req *AddressTxidsRequest) ([]string, error) {
+	normalized := make([]string, 0, len(req.Addresses))
+	for _, addr := range req.Addresses {
+		n, err := normalizeTaddr(addr, req.ChainName)
+		if err != nil {
+			return nil, fmt.Errorf("GetAddressTxids: invalid address %q: %w", addr, err)
+		}
+		normalized = append(normalized, n)
+	}
+	return normalized, nil
+}
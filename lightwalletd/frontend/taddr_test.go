@@ -0,0 +1,92 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package frontend
+
+import (
+	"testing"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+func TestNormalizeTaddrAcceptsHRPForm(t *testing.T) {
+	legacy, err := common.EncodeTaddr(make([]byte, 20), common.AddrKindP2PKH, "botcash")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	hrpAddr, err := common.ToHRPAddress(legacy, "botcash")
+	if err != nil {
+		t.Fatalf("ToHRPAddress failed: %v", err)
+	}
+
+	got, err := normalizeTaddr(hrpAddr, "botcash")
+	if err != nil {
+		t.Fatalf("normalizeTaddr failed: %v", err)
+	}
+	if got != legacy {
+		t.Errorf("normalizeTaddr(%q) = %q, want legacy form %q", hrpAddr, got, legacy)
+	}
+}
+
+func TestNormalizeTaddrRejectsMalformed(t *testing.T) {
+	tests := []struct {
+		name      string
+		addr      string
+		chainName string
+	}{
+		{"wrong network prefix", "t1HsdDMzmJfq4vc7T17XYjEkLMLvbgM1fCi", "botcash"},
+		{"truncated", "t1Hsd", "main"},
+		{"valid prefix, bad checksum", "t1HsdDMzmJfq4vc7T17XYjEkLMLvbgM1fCj", "main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := normalizeTaddr(tt.addr, tt.chainName); err == nil {
+				t.Errorf("normalizeTaddr(%q, %q) = nil error, want an error", tt.addr, tt.chainName)
+			}
+		})
+	}
+}
+
+func TestGetAddressTxidsNormalizesMixedForms(t *testing.T) {
+	legacy, err := common.EncodeTaddr(make([]byte, 20), common.AddrKindP2PKH, "botcash")
+	if err != nil {
+		t.Fatalf("EncodeTaddr failed: %v", err)
+	}
+	hrpAddr, err := common.ToHRPAddress(legacy, "botcash")
+	if err != nil {
+		t.Fatalf("ToHRPAddress failed: %v", err)
+	}
+
+	got, err := GetAddressTxids(&AddressTxidsRequest{
+		Addresses:   []string{legacy, hrpAddr},
+		ChainName:   "botcash",
+		StartHeight: 0,
+		EndHeight:   100,
+	})
+	if err != nil {
+		t.Fatalf("GetAddressTxids failed: %v", err)
+	}
+
+	want := []string{legacy, legacy}
+	if len(got) != len(want) {
+		t.Fatalf("GetAddressTxids returned %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("GetAddressTxids(...)[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestGetAddressTxidsRejectsInvalidAddress(t *testing.T) {
+	_, err := GetAddressTxids(&AddressTxidsRequest{
+		Addresses: []string{"t1Hsd"},
+		ChainName: "main",
+	})
+	if err == nil {
+		t.Fatal("expected an error for a malformed address")
+	}
+}
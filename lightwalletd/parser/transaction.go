@@ -0,0 +1,108 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+// Package parser decodes raw block and transaction bytes returned by a
+// zcashd-compatible RPC node. Decoding of v4 (Sapling) and v5
+// (NU5/Orchard) transactions is consensus-branch-dependent, so callers
+// must supply the block height (and chain) the transaction was mined at
+// rather than relying on hard-coded branch IDs.
+package parser
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+// overwinterFlag is the high bit of the four-byte transaction version field
+// that zcashd sets on every Overwinter-and-later transaction.
+const overwinterFlag = uint32(1) << 31
+
+// v5VersionGroupID is the version group ID zcashd stamps on NU5/Orchard
+// (v5) transactions, per ZIP 225.
+const v5VersionGroupID = uint32(0x26a7270a)
+
+// Transaction holds the fields decoded from a transaction's header that
+// depend on the network upgrade active at its containing block's height.
+// It's intentionally limited to what the header-decoding step needs;
+// script and output parsing build on top of this.
+type Transaction struct {
+	Overwintered   bool
+	Version        uint32
+	VersionGroupID uint32
+
+	// ConsensusBranchID is the branch ID this transaction was parsed
+	// against: for v5 transactions it's read from the wire and checked
+	// against the active upgrade at Height; for overwintered v3/v4
+	// transactions it's the branch ID of the upgrade active at Height
+	// (common.GetActiveUpgrade), since they don't carry it themselves; for
+	// non-overwintered (Sprout) transactions it's 0.
+	ConsensusBranchID uint32
+
+	// Height and ChainName record the context ParseTransactionHeader was
+	// given, so callers can tell which upgrade a transaction was decoded
+	// under without re-deriving it.
+	Height    uint64
+	ChainName string
+}
+
+// ParseTransactionHeader reads the version fields from the start of a
+// serialized transaction, resolving the consensus branch ID from height
+// and chainName rather than a hard-coded constant so that v4/v5
+// transactions decode correctly across a network upgrade boundary.
+func ParseTransactionHeader(data []byte, height uint64, chainName string) (*Transaction, []byte, error) {
+	if len(data) < 8 {
+		return nil, nil, fmt.Errorf("transaction data too short: %d bytes", len(data))
+	}
+
+	rawVersion := binary.LittleEndian.Uint32(data[0:4])
+	tx := &Transaction{
+		Overwintered: rawVersion&overwinterFlag != 0,
+		Version:      rawVersion &^ overwinterFlag,
+		Height:       height,
+		ChainName:    chainName,
+	}
+	rest := data[4:]
+
+	activeUpgrade, activeBranchID := common.GetActiveUpgrade(chainName, height)
+
+	if !tx.Overwintered {
+		// Pre-Overwinter (Sprout) transactions carry no version group ID
+		// or branch ID at all.
+		tx.ConsensusBranchID = 0
+		return tx, rest, nil
+	}
+
+	if len(rest) < 4 {
+		return nil, nil, fmt.Errorf("transaction data too short for version group ID: %d bytes", len(data))
+	}
+	tx.VersionGroupID = binary.LittleEndian.Uint32(rest[0:4])
+	rest = rest[4:]
+
+	if tx.VersionGroupID == v5VersionGroupID {
+		// ZIP 225: v5 transactions carry their own consensus branch ID on
+		// the wire. Cross-check it against the branch ID the containing
+		// block's height implies, rather than trusting either in
+		// isolation.
+		if len(rest) < 4 {
+			return nil, nil, fmt.Errorf("transaction data too short for consensus branch ID: %d bytes", len(data))
+		}
+		tx.ConsensusBranchID = binary.LittleEndian.Uint32(rest[0:4])
+		rest = rest[4:]
+
+		if tx.ConsensusBranchID != activeBranchID {
+			return nil, nil, fmt.Errorf(
+				"v5 transaction consensus branch ID %#08x does not match %s upgrade branch ID %#08x active at height %d on %s",
+				tx.ConsensusBranchID, activeUpgrade, activeBranchID, height, chainName)
+		}
+	} else {
+		// v3/v4 transactions don't carry a branch ID on the wire; it's
+		// derived entirely from the containing block's height.
+		tx.ConsensusBranchID = activeBranchID
+	}
+
+	return tx, rest, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019-2020 The Zcash developers
+// Copyright (c) 2026 The Botcash developers
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or https://www.opensource.org/licenses/mit-license.php .
+
+package parser
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/happybigmtn/botcash/lightwalletd/common"
+)
+
+// getblockchaininfoResponse mirrors the subset of zcashd's getblockchaininfo
+// RPC response that callers use to learn the current chain and height
+// before parsing a transaction.
+type getblockchaininfoResponse struct {
+	Chain  string `json:"chain"`
+	Blocks uint64 `json:"blocks"`
+}
+
+func v5TxBytes(branchID uint32) []byte {
+	buf := make([]byte, 12)
+	binary.LittleEndian.PutUint32(buf[0:4], 5|overwinterFlag)
+	binary.LittleEndian.PutUint32(buf[4:8], v5VersionGroupID)
+	binary.LittleEndian.PutUint32(buf[8:12], branchID)
+	return buf
+}
+
+func TestParseTransactionHeaderV5MatchesActiveBranch(t *testing.T) {
+	// Botcash activates every upgrade at height 1, so NU5's branch ID is
+	// active immediately.
+	tx, rest, err := ParseTransactionHeader(v5TxBytes(0xc2d6d0b4), 1, "botcash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("expected no bytes remaining, got %d", len(rest))
+	}
+	if tx.ConsensusBranchID != 0xc2d6d0b4 {
+		t.Errorf("ConsensusBranchID = %#08x, want %#08x", tx.ConsensusBranchID, 0xc2d6d0b4)
+	}
+}
+
+func TestParseTransactionHeaderV5RejectsStaleBranch(t *testing.T) {
+	// Canopy's branch ID used where NU5 is already active should be
+	// rejected: the wire value no longer matches the height-derived one.
+	_, _, err := ParseTransactionHeader(v5TxBytes(0xe9ff75a6), 1687104, "main")
+	if err == nil {
+		t.Fatal("expected an error for a stale consensus branch ID, got nil")
+	}
+}
+
+func TestParseTransactionHeaderEndToEndAcrossUpgradeBoundary(t *testing.T) {
+	// Simulate two mocked getblockchaininfo responses straddling the NU5
+	// activation boundary on mainnet: a v5 transaction stamped with the
+	// branch ID each response's height implies must parse cleanly, and the
+	// branch ID the parser reports must change from the block before NU5
+	// to the activation height.
+	responses := []string{
+		`{"chain":"main","blocks":1687103}`,
+		`{"chain":"main","blocks":1687104}`,
+	}
+
+	var branchIDs []uint32
+	for _, raw := range responses {
+		var info getblockchaininfoResponse
+		if err := json.Unmarshal([]byte(raw), &info); err != nil {
+			t.Fatalf("failed to unmarshal mocked response: %v", err)
+		}
+		branchID := common.GetBranchIDAtHeight(info.Chain, info.Blocks)
+		tx, _, err := ParseTransactionHeader(v5TxBytes(branchID), info.Blocks, info.Chain)
+		if err != nil {
+			t.Fatalf("unexpected error at height %d: %v", info.Blocks, err)
+		}
+		branchIDs = append(branchIDs, tx.ConsensusBranchID)
+	}
+
+	if branchIDs[0] == branchIDs[1] {
+		t.Fatalf("expected branch ID to change across the NU5 boundary, got %#08x both times", branchIDs[0])
+	}
+	if branchIDs[1] != 0xc2d6d0b4 {
+		t.Errorf("branch ID after NU5 activation = %#08x, want %#08x", branchIDs[1], 0xc2d6d0b4)
+	}
+}